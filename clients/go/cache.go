@@ -0,0 +1,236 @@
+package dinja
+
+import (
+	"compress/gzip"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache is a pluggable store for rendered Results, keyed by a stable hash
+// of the render request. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached Result for key, if present and not expired.
+	Get(key string) (*Result, bool)
+	// Set stores r under key for ttl. A zero ttl means no expiry.
+	Set(key string, r *Result, ttl time.Duration)
+}
+
+// WithCache enables content-addressed caching on the Renderer: before
+// issuing the HTTP call, doRender computes a stable hash over the render
+// request and consults cache, storing successful results for ttl.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(r *Renderer) {
+		r.cache = cache
+		r.cacheTTL = ttl
+	}
+}
+
+// WithCacheKeyPrefix namespaces cache keys so multiple Renderer instances
+// can share a single Cache (for example the same on-disk FSCache) without
+// colliding.
+func WithCacheKeyPrefix(prefix string) Option {
+	return func(r *Renderer) {
+		r.cacheKeyPrefix = prefix
+	}
+}
+
+// cacheKey computes a stable SHA-256 over (output, canonical-JSON(input)),
+// canonicalizing map key order so Views, Components, and Directives produce
+// deterministic bytes regardless of map iteration order.
+func cacheKey(prefix, output string, input Input) string {
+	h := sha256.New()
+	h.Write([]byte(output))
+	h.Write([]byte{0})
+	h.Write(canonicalJSON(input))
+
+	sum := prefix + hex.EncodeToString(h.Sum(nil))
+	return sum
+}
+
+// canonicalJSON marshals input with map keys sorted, so equivalent inputs
+// always produce identical bytes.
+func canonicalJSON(input Input) []byte {
+	type canonicalInput struct {
+		Views      []kv     `json:"mdx"`
+		Components []kv     `json:"components,omitempty"`
+		Utils      string   `json:"utils,omitempty"`
+		Minify     *bool    `json:"minify,omitempty"`
+		Directives []string `json:"directives,omitempty"`
+	}
+
+	c := canonicalInput{
+		Utils:      input.Utils,
+		Minify:     input.Minify,
+		Directives: append([]string(nil), input.Directives...),
+	}
+
+	for name, content := range input.Views {
+		c.Views = append(c.Views, kv{Key: name, Value: content})
+	}
+	sort.Slice(c.Views, func(i, j int) bool { return c.Views[i].Key < c.Views[j].Key })
+
+	for name, comp := range input.Components {
+		encoded, _ := json.Marshal(comp)
+		c.Components = append(c.Components, kv{Key: name, Value: string(encoded)})
+	}
+	sort.Slice(c.Components, func(i, j int) bool { return c.Components[i].Key < c.Components[j].Key })
+
+	data, _ := json.Marshal(c)
+	return data
+}
+
+type kv struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// MemoryCache is an in-process LRU Cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key       string
+	result    *Result
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates a Cache backed by an in-process LRU with room for
+// at most maxEntries results.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (*Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.result, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, r *Result, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		el.Value.(*memoryCacheEntry).result = r
+		el.Value.(*memoryCacheEntry).expiresAt = expiresAt
+		return
+	}
+
+	el := c.ll.PushFront(&memoryCacheEntry{key: key, result: r, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
+
+// FSCache is a Cache backed by gzipped JSON Result blobs on disk, suitable
+// for CI or static-site generation where re-running against unchanged MDX
+// should be free.
+type FSCache struct {
+	dir string
+}
+
+type fsCacheEntry struct {
+	Result    *Result   `json:"result"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// NewFSCache creates a Cache that persists entries under dir, creating it
+// if necessary.
+func NewFSCache(dir string) *FSCache {
+	return &FSCache{dir: dir}
+}
+
+func (c *FSCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json.gz")
+}
+
+// Get implements Cache.
+func (c *FSCache) Get(key string) (*Result, bool) {
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, false
+	}
+	defer gz.Close()
+
+	var entry fsCacheEntry
+	if err := json.NewDecoder(gz).Decode(&entry); err != nil {
+		return nil, false
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return entry.Result, true
+}
+
+// Set implements Cache.
+func (c *FSCache) Set(key string, r *Result, ttl time.Duration) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	f, err := os.Create(c.path(key))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+
+	json.NewEncoder(gz).Encode(fsCacheEntry{Result: r, ExpiresAt: expiresAt})
+}
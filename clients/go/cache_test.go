@@ -0,0 +1,106 @@
+package dinja_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dinja "github.com/piny4man/dinja-go"
+)
+
+func TestWithCacheAvoidsSecondRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"test.mdx":{"success":true,"result":{"output":"ok"}}}}`))
+	}))
+	defer server.Close()
+
+	r := dinja.New(
+		dinja.WithBaseURL(server.URL),
+		dinja.WithCache(dinja.NewMemoryCache(10), time.Minute),
+	)
+
+	input := dinja.Input{Views: map[string]string{"test.mdx": "# Test"}}
+
+	first, err := r.HTML(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.CacheHit {
+		t.Error("expected first call to miss the cache")
+	}
+
+	second, err := r.HTML(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !second.CacheHit {
+		t.Error("expected second call to hit the cache")
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected 1 request to the service, got %d", calls)
+	}
+}
+
+func TestMemoryCacheEvictsLRU(t *testing.T) {
+	cache := dinja.NewMemoryCache(2)
+	cache.Set("a", &dinja.Result{Total: 1}, 0)
+	cache.Set("b", &dinja.Result{Total: 2}, 0)
+	cache.Set("c", &dinja.Result{Total: 3}, 0)
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected oldest entry to be evicted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("expected newest entry to remain")
+	}
+}
+
+func TestFSCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cache := dinja.NewFSCache(dir)
+
+	result := &dinja.Result{Total: 1, Succeeded: 1}
+	cache.Set("key", result, time.Minute)
+
+	got, ok := cache.Get("key")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if got.Total != 1 || got.Succeeded != 1 {
+		t.Errorf("unexpected cached result: %+v", got)
+	}
+}
+
+func TestWithCacheKeyPrefixAvoidsCollisions(t *testing.T) {
+	shared := dinja.NewMemoryCache(10)
+
+	serverA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"test.mdx":{"success":true,"result":{"output":"A"}}}}`))
+	}))
+	defer serverA.Close()
+
+	rA := dinja.New(dinja.WithBaseURL(serverA.URL), dinja.WithCache(shared, time.Minute), dinja.WithCacheKeyPrefix("a:"))
+	rB := dinja.New(dinja.WithBaseURL(serverA.URL), dinja.WithCache(shared, time.Minute), dinja.WithCacheKeyPrefix("b:"))
+
+	input := dinja.Input{Views: map[string]string{"test.mdx": "# Test"}}
+
+	if _, err := rA.HTML(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := rB.HTML(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CacheHit {
+		t.Error("expected differently-prefixed renderer to miss the shared cache")
+	}
+}
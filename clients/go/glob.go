@@ -0,0 +1,57 @@
+package dinja
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compileGlob translates a subset of shell glob syntax into a regular
+// expression anchored to a full path: "*" matches within a path segment,
+// "**" matches across segments, and "{a,b}" expands to an alternation.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				sb.WriteString(".*")
+				i += 2
+				if i < len(pattern) && pattern[i] == '/' {
+					i++
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+			i++
+		case '?':
+			sb.WriteString("[^/]")
+			i++
+		case '.':
+			sb.WriteString(`\.`)
+			i++
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+				i++
+				continue
+			}
+			alts := strings.Split(pattern[i+1:i+end], ",")
+			for j, alt := range alts {
+				alts[j] = regexp.QuoteMeta(alt)
+			}
+			sb.WriteString("(")
+			sb.WriteString(strings.Join(alts, "|"))
+			sb.WriteString(")")
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			i++
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
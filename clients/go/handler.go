@@ -0,0 +1,242 @@
+package dinja
+
+import (
+	"bytes"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// defaultIndexFile is served when a request path resolves to a directory.
+const defaultIndexFile = "index.mdx"
+
+// defaultErrorTemplate is used when WithErrorTemplate is not supplied.
+var defaultErrorTemplate = template.Must(template.New("error").Parse(
+	`<!DOCTYPE html><html><head><title>Render Error</title></head>` +
+		`<body><h1>500 Internal Server Error</h1><p>{{.File}}</p><pre>{{.Message}}</pre></body></html>`,
+))
+
+// contentTypes maps an Output to the Content-Type written in the response.
+var contentTypes = map[Output]string{
+	OutputHTML:       "text/html; charset=utf-8",
+	OutputJavaScript: "application/javascript; charset=utf-8",
+	OutputSchema:     "application/json; charset=utf-8",
+	OutputJSON:       "application/json; charset=utf-8",
+}
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*handler)
+
+// WithRenderer supplies a pre-configured Renderer (for example one pointed
+// at a non-default base URL) instead of the handler's default, New().
+func WithRenderer(r *Renderer) HandlerOption {
+	return func(h *handler) {
+		h.renderer = r
+	}
+}
+
+// WithComponentsFS supplies a shared set of JSX/TSX components, read from
+// fsys and attached to every request's Input.Components.
+func WithComponentsFS(fsys fs.FS, glob string) HandlerOption {
+	return func(h *handler) {
+		h.componentsFS = fsys
+		h.componentsGlob = glob
+	}
+}
+
+// WithUtils sets the global JavaScript utilities shared by every request.
+func WithUtils(utils string) HandlerOption {
+	return func(h *handler) {
+		h.utils = utils
+	}
+}
+
+// WithOutput sets the default output format used when the request's Accept
+// header does not match a known Dinja media type.
+func WithOutput(output Output) HandlerOption {
+	return func(h *handler) {
+		h.defaultOutput = output
+	}
+}
+
+// WithErrorTemplate overrides the template used to render a FileResult with
+// Success == false. It is executed with the ErrorInfo as its data.
+func WithErrorTemplate(tmpl *template.Template) HandlerOption {
+	return func(h *handler) {
+		h.errorTemplate = tmpl
+	}
+}
+
+// WithMetadataHook registers a callback invoked with the rendered file's
+// frontmatter Metadata before the response body is written, so callers can
+// set headers such as Cache-Control or Last-Modified from view metadata.
+func WithMetadataHook(hook func(http.ResponseWriter, map[string]any)) HandlerOption {
+	return func(h *handler) {
+		h.metadataHook = hook
+	}
+}
+
+// Handler adapts a Renderer into an http.Handler that serves rendered MDX
+// from root, mapping request paths to files with directory-index fallback
+// (e.g. "/" -> "index.mdx", "/guide/" -> "guide/index.mdx").
+func Handler(root fs.FS, opts ...HandlerOption) http.Handler {
+	h := &handler{
+		root:          root,
+		renderer:      New(),
+		defaultOutput: OutputHTML,
+		errorTemplate: defaultErrorTemplate,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+type handler struct {
+	root           fs.FS
+	renderer       *Renderer
+	componentsFS   fs.FS
+	componentsGlob string
+	utils          string
+	defaultOutput  Output
+	errorTemplate  *template.Template
+	metadataHook   func(http.ResponseWriter, map[string]any)
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	file, ok := h.resolve(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	content, err := fs.ReadFile(h.root, file)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	input := Input{Views: map[string]string{file: string(content)}, Utils: h.utils}
+	if h.componentsFS != nil {
+		if components, err := h.loadComponents(); err == nil {
+			input.Components = components
+		}
+	}
+
+	output := h.outputFor(r)
+
+	result, err := h.renderer.Render(r.Context(), output, input)
+	if err != nil {
+		h.writeError(w, &ErrorInfo{File: file, Message: err.Error()})
+		return
+	}
+
+	fr, ok := result.Files[file]
+	if !ok || !fr.Success {
+		msg := "no result for file"
+		if ok {
+			msg = fr.Error
+		}
+		h.writeError(w, &ErrorInfo{File: file, Message: msg})
+		return
+	}
+
+	if h.metadataHook != nil && fr.Result != nil {
+		h.metadataHook(w, fr.Result.Metadata)
+	}
+
+	w.Header().Set("Content-Type", contentTypes[output])
+	w.Write([]byte(fr.Result.Output))
+}
+
+// loadComponents walks componentsFS, turning every file matching
+// componentsGlob into a Component keyed by its full relative path (so that
+// same-named components in different directories, or with different
+// extensions, don't collide); the Component's Name is still just the base
+// filename. Matching uses the same compileGlob dialect as LoadInputFS, so a
+// glob such as "components/**/*.{jsx,tsx}" behaves identically here.
+func (h *handler) loadComponents() (map[string]Component, error) {
+	glob := h.componentsGlob
+	if glob == "" {
+		glob = "*.jsx"
+	}
+
+	re, err := compileGlob(glob)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make(map[string]Component)
+
+	err = fs.WalkDir(h.componentsFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !re.MatchString(p) {
+			return err
+		}
+
+		content, err := fs.ReadFile(h.componentsFS, p)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+		components[p] = Component{Code: string(content), Name: name}
+		return nil
+	})
+
+	return components, err
+}
+
+// resolve maps a request path to a file within root, applying the
+// directory-index fallback. It reports false when no matching file exists.
+func (h *handler) resolve(urlPath string) (string, bool) {
+	clean := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if clean == "" || clean == "." {
+		clean = defaultIndexFile
+	}
+
+	if info, err := fs.Stat(h.root, clean); err == nil && !info.IsDir() {
+		return clean, true
+	}
+
+	withExt := clean + ".mdx"
+	if _, err := fs.Stat(h.root, withExt); err == nil {
+		return withExt, true
+	}
+
+	index := path.Join(clean, defaultIndexFile)
+	if _, err := fs.Stat(h.root, index); err == nil {
+		return index, true
+	}
+
+	return "", false
+}
+
+// outputFor chooses a render output based on the request's Accept header,
+// falling back to the handler's configured default.
+func (h *handler) outputFor(r *http.Request) Output {
+	switch accept := r.Header.Get("Accept"); {
+	case strings.Contains(accept, "application/javascript"):
+		return OutputJavaScript
+	case strings.Contains(accept, "application/json"):
+		return OutputJSON
+	case strings.Contains(accept, "text/html"):
+		return OutputHTML
+	default:
+		return h.defaultOutput
+	}
+}
+
+func (h *handler) writeError(w http.ResponseWriter, info *ErrorInfo) {
+	var buf bytes.Buffer
+	if err := h.errorTemplate.Execute(&buf, info); err != nil {
+		http.Error(w, info.Message, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write(buf.Bytes())
+}
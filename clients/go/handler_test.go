@@ -0,0 +1,205 @@
+package dinja_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	dinja "github.com/piny4man/dinja-go"
+)
+
+func TestHandlerServesMappedFile(t *testing.T) {
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"index.mdx":{"success":true,"result":{"metadata":{},"output":"<h1>Home</h1>"}}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"index.mdx": {Data: []byte("# Home")},
+	}
+
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+		dinja.WithOutput(dinja.OutputHTML),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlerServesSubdirectoryIndex(t *testing.T) {
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"guide/index.mdx":{"success":true,"result":{"metadata":{},"output":"<h1>Guide</h1>"}}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"guide/index.mdx": {Data: []byte("# Guide")},
+	}
+
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+		dinja.WithOutput(dinja.OutputHTML),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/guide/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlerNotFound(t *testing.T) {
+	root := fstest.MapFS{
+		"index.mdx": {Data: []byte("# Home")},
+	}
+
+	h := dinja.Handler(root)
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestHandlerMetadataHook(t *testing.T) {
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"page.mdx":{"success":true,"result":{"metadata":{"cache":"max-age=60"},"output":"ok"}}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"page.mdx": {Data: []byte("# Page")},
+	}
+
+	var gotHeader string
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+		dinja.WithMetadataHook(func(w http.ResponseWriter, metadata map[string]any) {
+			if cache, ok := metadata["cache"].(string); ok {
+				w.Header().Set("Cache-Control", cache)
+				gotHeader = cache
+			}
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/page", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if gotHeader != "max-age=60" {
+		t.Errorf("expected metadata hook to observe cache header, got %q", gotHeader)
+	}
+}
+
+func TestHandlerRendersErrorTemplateOnFailure(t *testing.T) {
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":0,"failed":1,"files":{"bad.mdx":{"success":false,"error":"syntax error"}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"bad.mdx": {Data: []byte("bad {{{")},
+	}
+
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/bad", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "<!DOCTYPE html>") || !strings.Contains(rec.Body.String(), "syntax error") {
+		t.Errorf("expected the HTML error template to render, got: %s", rec.Body.String())
+	}
+}
+
+func TestHandlerComponentsFSKeysByPath(t *testing.T) {
+	var gotNames []string
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input dinja.Input
+		json.NewDecoder(r.Body).Decode(&input)
+		for name := range input.Components {
+			gotNames = append(gotNames, name)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"index.mdx":{"success":true,"result":{"output":"ok"}}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"index.mdx": {Data: []byte("# Home")},
+	}
+	components := fstest.MapFS{
+		"a/Button.jsx": {Data: []byte("export default function Button() {}")},
+		"b/Button.jsx": {Data: []byte("export default function OtherButton() {}")},
+	}
+
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+		dinja.WithComponentsFS(components, "**/*.jsx"),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if len(gotNames) != 2 {
+		t.Errorf("expected both same-named components to survive, got %v", gotNames)
+	}
+}
+
+func TestHandlerComponentsFSGlobWithSlash(t *testing.T) {
+	var gotNames []string
+	renderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input dinja.Input
+		json.NewDecoder(r.Body).Decode(&input)
+		for name := range input.Components {
+			gotNames = append(gotNames, name)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"index.mdx":{"success":true,"result":{"output":"ok"}}}}`))
+	}))
+	defer renderServer.Close()
+
+	root := fstest.MapFS{
+		"index.mdx": {Data: []byte("# Home")},
+	}
+	components := fstest.MapFS{
+		"components/nested/Button.jsx": {Data: []byte("export default function Button() {}")},
+	}
+
+	h := dinja.Handler(root,
+		dinja.WithRenderer(dinja.New(dinja.WithBaseURL(renderServer.URL))),
+		dinja.WithComponentsFS(components, "components/**/*.{jsx,tsx}"),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if len(gotNames) != 1 {
+		t.Fatalf("expected a glob containing '/' to still match nested files, got %v", gotNames)
+	}
+}
@@ -0,0 +1,147 @@
+package dinja
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+const (
+	defaultViewsGlob      = "**/*.mdx"
+	defaultComponentsGlob = "components/**/*.{jsx,tsx}"
+)
+
+// LoadOption configures LoadInputFS.
+type LoadOption func(*loadConfig)
+
+type loadConfig struct {
+	viewsGlob      string
+	componentsGlob string
+	utilsFile      string
+}
+
+// WithViewsGlob overrides the glob used to find MDX view files. The default
+// is "**/*.mdx".
+func WithViewsGlob(glob string) LoadOption {
+	return func(c *loadConfig) {
+		c.viewsGlob = glob
+	}
+}
+
+// WithComponentsGlob overrides the glob used to find component files. The
+// default is "components/**/*.{jsx,tsx}".
+func WithComponentsGlob(glob string) LoadOption {
+	return func(c *loadConfig) {
+		c.componentsGlob = glob
+	}
+}
+
+// WithUtilsFile slurps the named file as the shared utilities script.
+func WithUtilsFile(name string) LoadOption {
+	return func(c *loadConfig) {
+		c.utilsFile = name
+	}
+}
+
+// LoadInputFS walks fsys, collecting MDX views and JSX/TSX components into
+// an Input, eliminating the boilerplate of walking directories and
+// populating the Views/Components maps by hand. It is suited to
+// embed.FS-based static-site pipelines where a whole content directory is
+// compiled into one binary and rendered in a single call.
+//
+// Relative paths (using fsys as the root) are used as view map keys.
+// Component files auto-populate Component.Name from the base filename and
+// Component.Code from file contents; a co-located ".docs.md" file becomes
+// Component.Docs, and a ".args.json" file becomes Component.Args.
+func LoadInputFS(fsys fs.FS, opts ...LoadOption) (Input, error) {
+	cfg := &loadConfig{
+		viewsGlob:      defaultViewsGlob,
+		componentsGlob: defaultComponentsGlob,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	viewsRe, err := compileGlob(cfg.viewsGlob)
+	if err != nil {
+		return Input{}, fmt.Errorf("compiling views glob: %w", err)
+	}
+	componentsRe, err := compileGlob(cfg.componentsGlob)
+	if err != nil {
+		return Input{}, fmt.Errorf("compiling components glob: %w", err)
+	}
+
+	input := Input{
+		Views:      make(map[string]string),
+		Components: make(map[string]Component),
+	}
+
+	err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		switch {
+		case componentsRe.MatchString(p):
+			comp, err := loadComponent(fsys, p)
+			if err != nil {
+				return fmt.Errorf("loading component %s: %w", p, err)
+			}
+			input.Components[p] = comp
+		case viewsRe.MatchString(p):
+			content, err := fs.ReadFile(fsys, p)
+			if err != nil {
+				return fmt.Errorf("reading view %s: %w", p, err)
+			}
+			input.Views[p] = string(content)
+		}
+		return nil
+	})
+	if err != nil {
+		return Input{}, err
+	}
+
+	if cfg.utilsFile != "" {
+		content, err := fs.ReadFile(fsys, cfg.utilsFile)
+		if err != nil {
+			return Input{}, fmt.Errorf("reading utils file: %w", err)
+		}
+		input.Utils = string(content)
+	}
+
+	if len(input.Components) == 0 {
+		input.Components = nil
+	}
+
+	return input, nil
+}
+
+// loadComponent reads the component at p along with its co-located docs
+// and args sidecar files, if present.
+func loadComponent(fsys fs.FS, p string) (Component, error) {
+	code, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return Component{}, err
+	}
+
+	base := strings.TrimSuffix(p, path.Ext(p))
+	name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+
+	comp := Component{Code: string(code), Name: name}
+
+	if docs, err := fs.ReadFile(fsys, base+".docs.md"); err == nil {
+		comp.Docs = string(docs)
+	}
+
+	if args, err := fs.ReadFile(fsys, base+".args.json"); err == nil {
+		var parsed any
+		if err := json.Unmarshal(args, &parsed); err != nil {
+			return Component{}, fmt.Errorf("parsing %s.args.json: %w", base, err)
+		}
+		comp.Args = parsed
+	}
+
+	return comp, nil
+}
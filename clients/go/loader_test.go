@@ -0,0 +1,128 @@
+package dinja_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	dinja "github.com/piny4man/dinja-go"
+)
+
+func TestLoadInputFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.mdx":                   {Data: []byte("# Home")},
+		"about.mdx":                   {Data: []byte("# About")},
+		"components/Button.jsx":       {Data: []byte("export default function Button() {}")},
+		"components/Button.docs.md":   {Data: []byte("A clickable button.")},
+		"components/Button.args.json": {Data: []byte(`{"label":"string"}`)},
+		"components/nested/Card.tsx":  {Data: []byte("export default function Card() {}")},
+		"utils.js":                    {Data: []byte("export const greet = () => 'hi'")},
+		"README.md":                   {Data: []byte("not a view")},
+	}
+
+	input, err := dinja.LoadInputFS(fsys, dinja.WithUtilsFile("utils.js"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(input.Views) != 2 {
+		t.Errorf("expected 2 views, got %d: %v", len(input.Views), input.Views)
+	}
+	if _, ok := input.Views["index.mdx"]; !ok {
+		t.Error("expected index.mdx to be loaded as a view")
+	}
+
+	button, ok := input.Components["components/Button.jsx"]
+	if !ok {
+		t.Fatal("expected Button component to be loaded")
+	}
+	if button.Name != "Button" {
+		t.Errorf("expected Name to be the base filename, got %q", button.Name)
+	}
+	if button.Docs != "A clickable button." {
+		t.Errorf("unexpected docs: %q", button.Docs)
+	}
+	if button.Args == nil {
+		t.Error("expected args to be populated")
+	}
+
+	if _, ok := input.Components["components/nested/Card.tsx"]; !ok {
+		t.Error("expected nested Card component to be loaded")
+	}
+
+	if input.Utils != "export const greet = () => 'hi'" {
+		t.Errorf("unexpected utils: %q", input.Utils)
+	}
+}
+
+func TestLoadInputFSKeysComponentsByPath(t *testing.T) {
+	fsys := fstest.MapFS{
+		"components/Button.jsx":        {Data: []byte("export default function Button() {}")},
+		"components/nested/Button.jsx": {Data: []byte("export default function NestedButton() {}")},
+	}
+
+	input, err := dinja.LoadInputFS(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(input.Components) != 2 {
+		t.Fatalf("expected both same-named components to be kept, got %d: %v", len(input.Components), input.Components)
+	}
+}
+
+func TestLoadInputFSKeepsSameNameDifferentExtension(t *testing.T) {
+	fsys := fstest.MapFS{
+		"components/Button.jsx": {Data: []byte("export default function Button() {}")},
+		"components/Button.tsx": {Data: []byte("export default function Button(): JSX.Element {}")},
+	}
+
+	input, err := dinja.LoadInputFS(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(input.Components) != 2 {
+		t.Fatalf("expected both same-named, differently-extensioned components to be kept, got %d: %v", len(input.Components), input.Components)
+	}
+}
+
+func TestLoadInputFSRejectsMalformedArgs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"components/Button.jsx":       {Data: []byte("export default function Button() {}")},
+		"components/Button.args.json": {Data: []byte("not json")},
+	}
+
+	if _, err := dinja.LoadInputFS(fsys); err == nil {
+		t.Fatal("expected error for malformed args.json")
+	}
+}
+
+func TestRenderOptionsWithViewsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page1.mdx": {Data: []byte("# Page 1")},
+		"page2.mdx": {Data: []byte("# Page 2")},
+		"notes.txt": {Data: []byte("ignored")},
+	}
+
+	input := dinja.NewRenderOptions().WithViewsFS(fsys, "").Build()
+
+	if len(input.Views) != 2 {
+		t.Errorf("expected 2 views, got %d", len(input.Views))
+	}
+}
+
+func TestLoadInputFSComponentsGlobDotIsLiteral(t *testing.T) {
+	fsys := fstest.MapFS{
+		"components/Button.jsx":  {Data: []byte("export default function Button() {}")},
+		"components/Button_json": {Data: []byte("not a component")},
+	}
+
+	input, err := dinja.LoadInputFS(fsys, dinja.WithComponentsGlob("components/*.{jsx,json}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(input.Components) != 1 {
+		t.Errorf("expected the dot in the glob to be literal, matched %d components: %v", len(input.Components), input.Components)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"time"
 )
@@ -44,6 +45,12 @@ type Renderer struct {
 	baseURL string
 	timeout time.Duration
 	client  *http.Client
+	retry   *retryConfig
+	breaker *circuitBreaker
+
+	cache          Cache
+	cacheTTL       time.Duration
+	cacheKeyPrefix string
 }
 
 // New creates a new Renderer with the given options.
@@ -119,40 +126,114 @@ func (r *Renderer) doRender(ctx context.Context, output string, input Input) (*R
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
+	if r.cache != nil {
+		key := cacheKey(r.cacheKeyPrefix, output, input)
+		if cached, ok := r.cache.Get(key); ok {
+			hit := *cached
+			hit.CacheHit = true
+			return &hit, nil
+		}
+
+		result, err := r.doRenderUncached(ctx, output, body)
+		if err != nil {
+			return nil, err
+		}
+		r.cache.Set(key, result, r.cacheTTL)
+		return result, nil
+	}
+
+	return r.doRenderUncached(ctx, output, body)
+}
+
+func (r *Renderer) doRenderUncached(ctx context.Context, output string, body []byte) (*Result, error) {
 	url := fmt.Sprintf("%s/render/%s", r.baseURL, output)
+
+	attempts := 1
+	if r.retry != nil && r.retry.maxAttempts > attempts {
+		attempts = r.retry.maxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if r.breaker != nil && !r.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		result, wait, retryable, err := r.attemptRender(ctx, url, body)
+		if err == nil {
+			if r.breaker != nil {
+				r.breaker.recordSuccess()
+			}
+			return result, nil
+		}
+
+		if r.breaker != nil {
+			r.breaker.recordFailure()
+		}
+		lastErr = err
+
+		if !retryable || r.retry == nil || attempt == attempts-1 {
+			return nil, lastErr
+		}
+
+		if wait <= 0 {
+			wait = backoff(r.retry, attempt)
+		}
+		if err := waitForRetry(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// attemptRender issues a single HTTP round trip. It reports whether the
+// failure is retryable and an optional server-requested wait duration
+// (from a Retry-After header).
+func (r *Renderer) attemptRender(ctx context.Context, url string, body []byte) (*Result, time.Duration, bool, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, 0, false, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, true, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, 0, true, fmt.Errorf("reading response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		var errResp struct {
 			Error string `json:"error"`
 		}
-		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != "" {
-			return nil, fmt.Errorf("render failed: %s", errResp.Error)
+		json.Unmarshal(respBody, &errResp)
+
+		var msg error
+		if errResp.Error != "" {
+			msg = fmt.Errorf("render failed: %s", errResp.Error)
+		} else {
+			msg = fmt.Errorf("render failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		if retryableStatus(resp.StatusCode) {
+			wait, _ := retryAfter(resp.Header.Get("Retry-After"))
+			return nil, wait, true, msg
 		}
-		return nil, fmt.Errorf("render failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, 0, false, msg
 	}
 
 	var result Result
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("unmarshaling response: %w", err)
+		return nil, 0, false, fmt.Errorf("unmarshaling response: %w", err)
 	}
 
-	return &result, nil
+	return &result, 0, false, nil
 }
 
 // RenderOptions provides a builder pattern for render requests.
@@ -198,6 +279,33 @@ func (o *RenderOptions) WithComponentCode(name, code string) *RenderOptions {
 	return o
 }
 
+// WithViewsFS walks fsys, adding every file matching glob as a view keyed
+// by its path relative to fsys. An empty glob defaults to "**/*.mdx".
+func (o *RenderOptions) WithViewsFS(fsys fs.FS, glob string) *RenderOptions {
+	if glob == "" {
+		glob = defaultViewsGlob
+	}
+
+	re, err := compileGlob(glob)
+	if err != nil {
+		return o
+	}
+
+	fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !re.MatchString(p) {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		o.views[p] = string(content)
+		return nil
+	})
+
+	return o
+}
+
 // WithUtils sets the global utilities code.
 func (o *RenderOptions) WithUtils(utils string) *RenderOptions {
 	o.utils = utils
@@ -0,0 +1,188 @@
+package dinja
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by doRender when the circuit breaker is open
+// and requests are being short-circuited.
+var ErrCircuitOpen = errors.New("dinja: circuit breaker open")
+
+// BreakerState describes the current state of a Renderer's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed is the normal operating state: requests pass through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen means the failure threshold was reached; requests are
+	// rejected with ErrCircuitOpen until the cooldown elapses.
+	BreakerOpen
+	// BreakerHalfOpen admits a single probe request to test recovery.
+	BreakerHalfOpen
+)
+
+// retryConfig holds the parameters set by WithRetry.
+type retryConfig struct {
+	maxAttempts int
+	initial     time.Duration
+	max         time.Duration
+	jitter      float64
+}
+
+// WithRetry enables retries on transport errors and 5xx/429 responses, using
+// full-jitter exponential backoff: sleep = rand(0, min(max, initial*2^attempt)).
+// jitter scales the computed sleep (0 disables jitter, 1 is full jitter).
+func WithRetry(maxAttempts int, initial, max time.Duration, jitter float64) Option {
+	return func(r *Renderer) {
+		r.retry = &retryConfig{
+			maxAttempts: maxAttempts,
+			initial:     initial,
+			max:         max,
+			jitter:      jitter,
+		}
+	}
+}
+
+// WithCircuitBreaker trips the breaker after threshold consecutive failures,
+// short-circuiting requests with ErrCircuitOpen for cooldown before entering
+// a half-open state that admits one probe request.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(r *Renderer) {
+		r.breaker = &circuitBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+		}
+	}
+}
+
+// circuitBreaker tracks a rolling consecutive-failure count for a Renderer.
+type circuitBreaker struct {
+	mu            sync.Mutex
+	threshold     int
+	cooldown      time.Duration
+	failures      int
+	state         BreakerState
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probeInFlight = true
+		return true
+	case BreakerHalfOpen:
+		if b.probeInFlight {
+			return false
+		}
+		b.probeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.state = BreakerClosed
+	b.probeInFlight = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.probeInFlight = false
+		return
+	}
+
+	b.failures++
+	b.probeInFlight = false
+	if b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *circuitBreaker) currentState() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// BreakerState returns the current state of the Renderer's circuit breaker.
+// It returns BreakerClosed if WithCircuitBreaker was not configured.
+func (r *Renderer) BreakerState() BreakerState {
+	if r.breaker == nil {
+		return BreakerClosed
+	}
+	return r.breaker.currentState()
+}
+
+// retryableStatus reports whether a response status code should be retried.
+func retryableStatus(code int) bool {
+	return code == 429 || (code >= 500 && code <= 599)
+}
+
+// backoff computes a full-jitter exponential backoff duration for attempt.
+func backoff(cfg *retryConfig, attempt int) time.Duration {
+	d := cfg.initial * time.Duration(1<<uint(attempt))
+	if d > cfg.max || d <= 0 {
+		d = cfg.max
+	}
+	if cfg.jitter <= 0 {
+		return d
+	}
+	scaled := time.Duration(float64(d) * cfg.jitter)
+	if scaled <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(scaled)))
+}
+
+// retryAfter parses a Retry-After header value expressed in seconds.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// waitForRetry sleeps for d or returns ctx.Err() if the context is cancelled
+// first.
+func waitForRetry(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package dinja_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dinja "github.com/piny4man/dinja-go"
+)
+
+func TestWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"test.mdx":{"success":true,"result":{"output":"ok"}}}}`))
+	}))
+	defer server.Close()
+
+	r := dinja.New(
+		dinja.WithBaseURL(server.URL),
+		dinja.WithRetry(5, time.Millisecond, 10*time.Millisecond, 1.0),
+	)
+
+	result, err := r.HTML(context.Background(), dinja.Input{Views: map[string]string{"test.mdx": "# Test"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsAllSuccess() {
+		t.Error("expected success after retries")
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestWithRetryDoesNotRetryOn400(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	r := dinja.New(
+		dinja.WithBaseURL(server.URL),
+		dinja.WithRetry(5, time.Millisecond, 10*time.Millisecond, 1.0),
+	)
+
+	_, err := r.HTML(context.Background(), dinja.Input{Views: map[string]string{"test.mdx": "# Test"}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected no retries on 400, got %d calls", calls)
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := dinja.New(
+		dinja.WithBaseURL(server.URL),
+		dinja.WithCircuitBreaker(2, time.Minute),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.HTML(context.Background(), dinja.Input{Views: map[string]string{"test.mdx": "# Test"}}); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+
+	if r.BreakerState() != dinja.BreakerOpen {
+		t.Errorf("expected breaker to be open, got %v", r.BreakerState())
+	}
+
+	_, err := r.HTML(context.Background(), dinja.Input{Views: map[string]string{"test.mdx": "# Test"}})
+	if err != dinja.ErrCircuitOpen {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package dinja
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamEvent is a single file result delivered while streaming a batch
+// render, either from a line of NDJSON or synthesized from a buffered
+// fallback response.
+type StreamEvent struct {
+	// Filename is the view that produced this result.
+	Filename string
+	// Result is the outcome of rendering Filename.
+	Result FileResult
+}
+
+// ndjsonEvent is the wire shape of a single NDJSON line.
+type ndjsonEvent struct {
+	Filename string     `json:"filename"`
+	Result   FileResult `json:"result"`
+}
+
+// RenderStream renders a batch of views, invoking onFile as each result
+// becomes available instead of waiting for the full batch to complete. It
+// requests NDJSON streaming from the service and falls back to iterating a
+// buffered JSON Result if the server responds with one instead.
+func (r *Renderer) RenderStream(ctx context.Context, output Output, input Input, onFile func(filename string, fr FileResult)) error {
+	if input.Minify == nil {
+		minify := true
+		input.Minify = &minify
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/render/%s", r.baseURL, output)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("render failed with status %d", resp.StatusCode)
+	}
+
+	if isNDJSON(resp.Header.Get("Content-Type")) {
+		return streamNDJSON(ctx, resp.Body, onFile)
+	}
+
+	var result Result
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("unmarshaling response: %w", err)
+	}
+	for filename, fr := range result.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		onFile(filename, fr)
+	}
+	return nil
+}
+
+// RenderStreamChan is the channel-based counterpart to RenderStream, for
+// idiomatic consumption with range/select. The returned channel is closed
+// when the stream ends; a terminal error, if any, is sent as the final
+// StreamEvent's Result.Error with Result.Success set to false and Filename
+// empty. Once ctx is done, an event (including that final error) that the
+// receiver has not yet taken may be dropped instead of sent.
+func (r *Renderer) RenderStreamChan(ctx context.Context, output Output, input Input) <-chan StreamEvent {
+	events := make(chan StreamEvent)
+
+	go func() {
+		defer close(events)
+		err := r.RenderStream(ctx, output, input, func(filename string, fr FileResult) {
+			select {
+			case events <- StreamEvent{Filename: filename, Result: fr}:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			select {
+			case events <- StreamEvent{Result: FileResult{Success: false, Error: err.Error()}}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return events
+}
+
+func isNDJSON(contentType string) bool {
+	return contentType == "application/x-ndjson" || contentType == "application/x-ndjson; charset=utf-8"
+}
+
+// streamNDJSON reads newline-delimited ndjsonEvent-shaped JSON from body,
+// invoking onFile for each line as it arrives.
+func streamNDJSON(ctx context.Context, body io.Reader, onFile func(filename string, fr FileResult)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var event ndjsonEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("unmarshaling ndjson event: %w", err)
+		}
+		onFile(event.Filename, event.Result)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,94 @@
+package dinja_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dinja "github.com/piny4man/dinja-go"
+)
+
+func TestRenderStreamNDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected ResponseWriter to support flushing")
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, `{"filename":"page%d.mdx","result":{"success":true,"result":{"output":"page %d"}}}`+"\n", i, i)
+			flusher.Flush()
+			time.Sleep(5 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	r := dinja.New(dinja.WithBaseURL(server.URL))
+
+	var seen []string
+	err := r.RenderStream(context.Background(), dinja.OutputHTML, dinja.Input{
+		Views: map[string]string{"page1.mdx": "#1", "page2.mdx": "#2", "page3.mdx": "#3"},
+	}, func(filename string, fr dinja.FileResult) {
+		seen = append(seen, filename)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(seen))
+	}
+}
+
+func TestRenderStreamBufferedFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"succeeded":1,"files":{"test.mdx":{"success":true,"result":{"output":"ok"}}}}`))
+	}))
+	defer server.Close()
+
+	r := dinja.New(dinja.WithBaseURL(server.URL))
+
+	var got string
+	err := r.RenderStream(context.Background(), dinja.OutputHTML, dinja.Input{
+		Views: map[string]string{"test.mdx": "# Test"},
+	}, func(filename string, fr dinja.FileResult) {
+		got = filename
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "test.mdx" {
+		t.Errorf("expected test.mdx, got %s", got)
+	}
+}
+
+func TestRenderStreamChan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"filename":"a.mdx","result":{"success":true,"result":{"output":"a"}}}`)
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	r := dinja.New(dinja.WithBaseURL(server.URL))
+
+	events := r.RenderStreamChan(context.Background(), dinja.OutputHTML, dinja.Input{
+		Views: map[string]string{"a.mdx": "# A"},
+	})
+
+	count := 0
+	for range events {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("expected 1 event, got %d", count)
+	}
+}
@@ -73,6 +73,9 @@ type Result struct {
 	Files map[string]FileResult `json:"files"`
 	// Errors contains error information for failed files.
 	Errors []ErrorInfo `json:"errors,omitempty"`
+	// CacheHit indicates the result was served from a Cache rather than
+	// the render service. Not part of the wire format.
+	CacheHit bool `json:"-"`
 }
 
 // IsAllSuccess returns true if all files were rendered successfully.